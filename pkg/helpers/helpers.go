@@ -1,26 +1,29 @@
 package helpers
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
-	"encoding/base64"
-	"encoding/json"
-	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
-	"syscall"
-	"time"
 
-	"github.com/t1mon-ggg/gophkeeper/pkg/logging/zerolog"
-	"github.com/t1mon-ggg/gophkeeper/pkg/models"
+	"github.com/t1mon-ggg/gophkeeper/pkg/helpers/tui"
 	"golang.org/x/term"
 )
 
 var (
-	termState *term.State
-	cmds      []string = []string{
+	termState  *term.State
+	dispatcher = newDefaultDispatcher()
+)
+
+// newDefaultDispatcher - register the built-in TUI commands against the
+// fuzzy-matching dispatcher. Each Run only returns a "not implemented" error;
+// this dispatcher serves name lookup for FindCommand and SuggestCommand.
+func newDefaultDispatcher() *tui.Dispatcher {
+	d := tui.NewDispatcher()
+	for _, name := range []string{
 		"get",
 		"roster",
 		"revoke",
@@ -33,18 +36,28 @@ var (
 		"status",
 		"rollback",
 		"timemachine",
-	}
-)
+	} {
+		name := name
+		d.Register(&tui.Command{
+			Name: name,
+			Run: func(ctx context.Context, args []string) error {
+				return fmt.Errorf("%s: not implemented", name)
+			},
+		})
+	}
+	return d
+}
 
-// GenSecretKey - generates a random cryptographic sequence of bytes
+// GenSecretKey - generates a random cryptographic sequence of bytes into an
+// mlock'd, wipeable SecretBytes
 //		n - size of slice []byte{}
-func GenSecretKey(n int) ([]byte, error) {
-	data := make([]byte, n)
-	_, err := rand.Read(data)
-	if err != nil {
-		return []byte{}, err
+func GenSecretKey(n int) (*SecretBytes, error) {
+	s := NewSecretBytes(n)
+	if _, err := rand.Read(s.Bytes()); err != nil {
+		s.Wipe()
+		return nil, err
 	}
-	return data, nil
+	return s, nil
 }
 
 // FileExists - check file exist or not
@@ -53,7 +66,8 @@ func FileExists(path string) bool {
 	return err == nil
 }
 
-// GenHash - generate hashsum of []byte
+// GenHash - generate hashsum of []byte for content-integrity checks.
+// Secret/passphrase hashing should use DeriveKey/VerifyPassphrase instead.
 func GenHash(content []byte) string {
 	h := sha256.New()
 	h.Write(content)
@@ -69,17 +83,6 @@ func CompareHash(hashed string, content []byte) bool {
 	return strings.EqualFold(hashed, fmt.Sprintf("%x", hash))
 }
 
-// ReadSecret - read secret from stdin in security mode
-func ReadSecret(msg string) (string, error) {
-	fmt.Print(msg)
-	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
-	if err != nil {
-		return "", err
-	}
-	secret := string(bytePassword)
-	return secret, nil
-}
-
 // SaveTermState - save terminal state on start
 func SaveTermState() {
 	oldState, err := term.GetState(int(os.Stdin.Fd()))
@@ -96,67 +99,25 @@ func RestoreTermState() {
 	}
 }
 
-// FindCommand - find commant in TUI user input
+// FindCommand - find command in TUI user input by exact name or alias. It
+// never auto-resolves a fuzzy match; use SuggestCommand for that and confirm
+// with the user before treating it as equivalent to an exact match.
 func FindCommand(in string) (string, bool) {
-	for _, cmd := range cmds {
-		if strings.Contains(in, cmd) {
-			return cmd, true
-		}
+	cmd, ok := dispatcher.Find(in)
+	if !ok {
+		return "", false
 	}
-	return "", false
+	return cmd.Name, true
 }
 
-// GetNameFromToken - get vault name from jwt token value
-func GetNameFromToken(token string) (string, error) {
-	log := zerolog.New().WithPrefix("helper")
-	type p struct {
-		Name string `json:"name"`
-		Exp  int64  `json:"exp"`
-	}
-	tt := strings.Split(token, ".")
-	if len(tt) != 3 {
-		log.Debug(nil, "token parse error")
-		return "", errors.New("invalid token")
-	}
-	payload, err := base64.RawStdEncoding.DecodeString(tt[1])
-	if err != nil {
-		log.Debug(err, "base64 decode error")
-		return "", err
+// SuggestCommand - the closest fuzzy "did you mean" match for in, if any.
+// Callers must confirm with the user before running the suggested command.
+func SuggestCommand(in string) (string, bool) {
+	cmd, ok := dispatcher.Suggest(in)
+	if !ok {
+		return "", false
 	}
-	u := new(p)
-	err = json.Unmarshal(payload, u)
-	if err != nil {
-		log.Debug(err, "json unmarshal error")
-		return "", err
-	}
-	return u.Name, nil
-}
-
-// GetExpirationFromToken - get expiration time from jwt token value
-func GetExpirationFromToken(token string) (*time.Time, error) {
-	log := zerolog.New().WithPrefix("helper")
-	type p struct {
-		Name string `json:"name"`
-		Exp  int64  `json:"exp"`
-	}
-	tt := strings.Split(token, ".")
-	if len(tt) != 3 {
-		log.Debug(nil, "token parse error")
-		return nil, errors.New("invalid token")
-	}
-	payload, err := base64.RawStdEncoding.DecodeString(tt[1])
-	if err != nil {
-		log.Debug(err, "base64 decode error")
-		return nil, err
-	}
-	u := new(p)
-	err = json.Unmarshal(payload, u)
-	if err != nil {
-		log.Debug(err, "json unmarshal error")
-		return nil, err
-	}
-	t := time.Unix(u.Exp, 0)
-	return &t, nil
+	return cmd.Name, true
 }
 
 // IsFlagPassed - checking the using of the flag
@@ -169,37 +130,3 @@ func IsFlagPassed(name string) bool {
 	})
 	return found
 }
-
-// OnlyOne - remove duplicate versions
-func OnlyOne(in []models.Version) []models.Version {
-	var checked []string
-	var latests []models.Version
-	for _, vv := range in {
-		if included(checked, vv.Hash) {
-			continue
-		}
-		checked = append(checked, vv.Hash)
-		var timestamp time.Time
-		var latest string
-		for _, v := range in {
-			if vv.Hash == v.Hash {
-				if v.Date.After(timestamp) {
-					timestamp = v.Date
-					latest = v.Hash
-				}
-
-			}
-		}
-		latests = append(latests, models.Version{Date: timestamp, Hash: latest})
-	}
-	return latests
-}
-
-func included(hashes []string, hash string) bool {
-	for _, h := range hashes {
-		if h == hash {
-			return true
-		}
-	}
-	return false
-}
@@ -0,0 +1,126 @@
+package helpers
+
+import (
+	"crypto"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/t1mon-ggg/gophkeeper/pkg/logging/zerolog"
+)
+
+// Claims - registered and application claims extracted from a verified token
+type Claims struct {
+	Name string
+	Exp  int64
+	Iat  int64
+	Nbf  int64
+	Sub  string
+	Iss  string
+}
+
+// KeyFunc - resolves the public/shared key used to verify a token's signature
+// from its header (typically by inspecting the "kid" claim)
+type KeyFunc func(header map[string]interface{}) (crypto.PublicKey, error)
+
+// ClaimsLeeway - clock skew tolerance applied to exp/nbf/iat validation
+var ClaimsLeeway = 30 * time.Second
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Name string `json:"name"`
+}
+
+// ParseToken - parse and fully verify a JWT: signature (HS256/RS256/ES256 only,
+// "alg: none" is rejected), then standard registered claims with ClaimsLeeway
+// clock skew tolerance
+func ParseToken(token string, keyfunc KeyFunc) (*Claims, error) {
+	log := zerolog.New().WithPrefix("helper")
+	claims := new(tokenClaims)
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodHMAC, *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, errors.New("unsupported or disallowed signing method")
+		}
+		header := map[string]interface{}{}
+		for k, v := range t.Header {
+			header[k] = v
+		}
+		return keyfunc(header)
+	}, jwt.WithValidMethods([]string{"HS256", "RS256", "ES256"}), jwt.WithLeeway(ClaimsLeeway))
+	if err != nil {
+		log.Debug(err, "token verification error")
+		return nil, err
+	}
+	if !parsed.Valid {
+		log.Debug(nil, "token invalid")
+		return nil, errors.New("invalid token")
+	}
+	out := &Claims{Name: claims.Name, Sub: claims.Subject}
+	if claims.ExpiresAt != nil {
+		out.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		out.Iat = claims.IssuedAt.Unix()
+	}
+	if claims.NotBefore != nil {
+		out.Nbf = claims.NotBefore.Unix()
+	}
+	if len(claims.Issuer) > 0 {
+		out.Iss = claims.Issuer
+	}
+	return out, nil
+}
+
+// TokenStore - remembers the signing key(s)/JWKS fetched at login so that
+// tokens read back later are rejected if they no longer verify against it
+type TokenStore struct {
+	keyfunc KeyFunc
+}
+
+// NewTokenStore - build a TokenStore bound to a single keyfunc resolved once at login
+func NewTokenStore(keyfunc KeyFunc) *TokenStore {
+	return &TokenStore{keyfunc: keyfunc}
+}
+
+// Parse - verify token against the store's remembered key
+func (s *TokenStore) Parse(token string) (*Claims, error) {
+	if s == nil || s.keyfunc == nil {
+		return nil, errors.New("token store has no signing key configured")
+	}
+	return ParseToken(token, s.keyfunc)
+}
+
+// defaultTokenStore - the signing key remembered at login, used by the
+// GetNameFromToken/GetExpirationFromToken compatibility wrappers
+var defaultTokenStore *TokenStore
+
+// SetDefaultTokenStore - remember the signing key fetched at login so that
+// GetNameFromToken/GetExpirationFromToken can keep their original signatures
+// while still verifying instead of trusting an unchecked base64 payload
+func SetDefaultTokenStore(s *TokenStore) {
+	defaultTokenStore = s
+}
+
+// GetNameFromToken - get vault name from jwt token value. Fails closed when no
+// signing key has been registered via SetDefaultTokenStore.
+func GetNameFromToken(token string) (string, error) {
+	claims, err := defaultTokenStore.Parse(token)
+	if err != nil {
+		return "", err
+	}
+	return claims.Name, nil
+}
+
+// GetExpirationFromToken - get expiration time from jwt token value. Fails closed
+// when no signing key has been registered via SetDefaultTokenStore.
+func GetExpirationFromToken(token string) (*time.Time, error) {
+	claims, err := defaultTokenStore.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	t := time.Unix(claims.Exp, 0)
+	return &t, nil
+}
@@ -0,0 +1,102 @@
+package helpers
+
+import (
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testHMACSecret = []byte("test-signing-secret")
+
+func signTestToken(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := tok.SignedString(testHMACSecret)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return signed
+}
+
+func testKeyFunc(header map[string]interface{}) (crypto.PublicKey, error) {
+	return testHMACSecret, nil
+}
+
+func TestParseTokenValidToken(t *testing.T) {
+	now := time.Now()
+	signed := signTestToken(t, tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   "vault-1",
+		},
+		Name: "my-vault",
+	})
+
+	claims, err := ParseToken(signed, testKeyFunc)
+	if err != nil {
+		t.Fatalf("ParseToken: %v", err)
+	}
+	if claims.Name != "my-vault" {
+		t.Errorf("Name = %q, want %q", claims.Name, "my-vault")
+	}
+	if claims.Sub != "vault-1" {
+		t.Errorf("Sub = %q, want %q", claims.Sub, "vault-1")
+	}
+}
+
+func TestParseTokenRejectsExpired(t *testing.T) {
+	now := time.Now()
+	signed := signTestToken(t, tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(-time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now.Add(-2 * time.Hour)),
+		},
+		Name: "my-vault",
+	})
+
+	if _, err := ParseToken(signed, testKeyFunc); err == nil {
+		t.Fatal("ParseToken: expected an error for an expired token")
+	}
+}
+
+func TestParseTokenRejectsAlgNone(t *testing.T) {
+	signed := signTestToken(t, tokenClaims{Name: "my-vault"})
+	// Re-sign with alg:none by constructing the unsigned token directly.
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, tokenClaims{Name: "my-vault"})
+	noneToken, err := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("SignedString(none): %v", err)
+	}
+
+	if _, err := ParseToken(noneToken, testKeyFunc); err == nil {
+		t.Fatal("ParseToken: expected alg:none to be rejected")
+	}
+	if _, err := ParseToken(signed, testKeyFunc); err != nil {
+		t.Fatalf("ParseToken: valid HS256 token should still verify: %v", err)
+	}
+}
+
+func TestGetNameFromTokenUsesDefaultTokenStore(t *testing.T) {
+	defer SetDefaultTokenStore(nil)
+
+	signed := signTestToken(t, tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		Name:             "my-vault",
+	})
+
+	if _, err := GetNameFromToken(signed); err == nil {
+		t.Fatal("GetNameFromToken: expected a fail-closed error with no TokenStore configured")
+	}
+
+	SetDefaultTokenStore(NewTokenStore(testKeyFunc))
+	name, err := GetNameFromToken(signed)
+	if err != nil {
+		t.Fatalf("GetNameFromToken: %v", err)
+	}
+	if name != "my-vault" {
+		t.Errorf("GetNameFromToken = %q, want %q", name, "my-vault")
+	}
+}
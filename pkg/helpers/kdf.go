@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// KDFParams - tunable argon2id cost parameters
+type KDFParams struct {
+	Memory      uint32 // in KiB
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultKDFParams - argon2id parameters used for new passphrase hashes
+var DefaultKDFParams = KDFParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+var errInvalidKDFHash = errors.New("invalid argon2id hash string")
+
+// DeriveKey - derive a key from a passphrase and salt using argon2id, into an
+// mlock'd, wipeable SecretBytes
+func DeriveKey(passphrase string, salt []byte, params KDFParams) *SecretBytes {
+	key := argon2.IDKey([]byte(passphrase), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	out := SecretBytesFrom(key)
+	for i := range key {
+		key[i] = 0
+	}
+	return out
+}
+
+// HashPassphrase - derive a key from passphrase with DefaultKDFParams and a fresh
+// random salt, returning a self-describing encoded string of the form
+// $argon2id$v=19$m=...,t=...,p=...$salt$hash
+func HashPassphrase(passphrase string) (string, error) {
+	saltBuf, err := GenSecretKey(int(DefaultKDFParams.SaltLength))
+	if err != nil {
+		return "", err
+	}
+	defer saltBuf.Wipe()
+	salt := saltBuf.Bytes()
+	key := DeriveKey(passphrase, salt, DefaultKDFParams)
+	defer key.Wipe()
+	return encodeKDFHash(DefaultKDFParams, salt, key.Bytes()), nil
+}
+
+// VerifyPassphrase - verify a passphrase against a previously encoded argon2id hash
+func VerifyPassphrase(encoded, passphrase string) (bool, error) {
+	params, salt, hash, err := decodeKDFHash(encoded)
+	if err != nil {
+		return false, err
+	}
+	candidate := DeriveKey(passphrase, salt, params)
+	defer candidate.Wipe()
+	return subtle.ConstantTimeCompare(candidate.Bytes(), hash) == 1, nil
+}
+
+// NeedsRehash - report whether an encoded hash was produced with outdated
+// parameters, so callers can re-hash on next successful unlock
+func NeedsRehash(encoded string) (bool, error) {
+	params, _, _, err := decodeKDFHash(encoded)
+	if err != nil {
+		return false, err
+	}
+	return params != DefaultKDFParams, nil
+}
+
+func encodeKDFHash(params KDFParams, salt, hash []byte) string {
+	b64Salt := base64.RawStdEncoding.EncodeToString(salt)
+	b64Hash := base64.RawStdEncoding.EncodeToString(hash)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism, b64Salt, b64Hash)
+}
+
+func decodeKDFHash(encoded string) (KDFParams, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return KDFParams{}, nil, nil, errInvalidKDFHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return KDFParams{}, nil, nil, errInvalidKDFHash
+	}
+	if version != argon2.Version {
+		return KDFParams{}, nil, nil, fmt.Errorf("unsupported argon2 version: %d", version)
+	}
+	params := KDFParams{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return KDFParams{}, nil, nil, errInvalidKDFHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return KDFParams{}, nil, nil, errInvalidKDFHash
+	}
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return KDFParams{}, nil, nil, errInvalidKDFHash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(hash))
+	return params, salt, hash, nil
+}
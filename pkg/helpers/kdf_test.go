@@ -0,0 +1,56 @@
+package helpers
+
+import "testing"
+
+func TestHashAndVerifyPassphrase(t *testing.T) {
+	encoded, err := HashPassphrase("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("HashPassphrase: %v", err)
+	}
+
+	ok, err := VerifyPassphrase(encoded, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("VerifyPassphrase: %v", err)
+	}
+	if !ok {
+		t.Fatal("VerifyPassphrase: expected match for the correct passphrase")
+	}
+
+	ok, err = VerifyPassphrase(encoded, "wrong passphrase")
+	if err != nil {
+		t.Fatalf("VerifyPassphrase: %v", err)
+	}
+	if ok {
+		t.Fatal("VerifyPassphrase: expected no match for the wrong passphrase")
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	encoded, err := HashPassphrase("hunter2")
+	if err != nil {
+		t.Fatalf("HashPassphrase: %v", err)
+	}
+	stale, err := NeedsRehash(encoded)
+	if err != nil {
+		t.Fatalf("NeedsRehash: %v", err)
+	}
+	if stale {
+		t.Fatal("NeedsRehash: a hash just produced with DefaultKDFParams should not need rehashing")
+	}
+
+	outdated := encodeKDFHash(KDFParams{Memory: 8 * 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		make([]byte, 16), make([]byte, 32))
+	stale, err = NeedsRehash(outdated)
+	if err != nil {
+		t.Fatalf("NeedsRehash: %v", err)
+	}
+	if !stale {
+		t.Fatal("NeedsRehash: a hash with outdated params should need rehashing")
+	}
+}
+
+func TestVerifyPassphraseRejectsMalformedHash(t *testing.T) {
+	if _, err := VerifyPassphrase("not-an-argon2-hash", "anything"); err == nil {
+		t.Fatal("VerifyPassphrase: expected an error for a malformed encoded hash")
+	}
+}
@@ -0,0 +1,41 @@
+package helpers
+
+import "os"
+
+// SecretPrompter - a backend capable of collecting a secret from the user.
+// Implementations cover a TTY, a pinentry helper, a GUI dialog, and
+// platform biometrics, so callers without a controlling TTY (daemons,
+// IDE terminals, desktop re-auth) still have a way to ask for a secret.
+type SecretPrompter interface {
+	Prompt(msg string) (*SecretBytes, error)
+}
+
+var activePrompter SecretPrompter = selectPrompter()
+
+// selectPrompter - pick a SecretPrompter from config/env, in order of
+// preference: an explicitly configured pinentry binary, platform
+// biometrics (if available and not disabled), a GUI dialog, then the TTY.
+func selectPrompter() SecretPrompter {
+	if bin := os.Getenv("GOPHKEEPER_PINENTRY"); bin != "" {
+		return &pinentryPrompter{binary: bin, userData: os.Getenv("PINENTRY_USER_DATA")}
+	}
+	if p := newBiometricsPrompter(); p != nil {
+		return p
+	}
+	if p := newGUIPrompter(); p != nil {
+		return p
+	}
+	return &ttyPrompter{}
+}
+
+// SetPrompter - override the active SecretPrompter, e.g. to force a
+// specific backend from the daemon/agent path
+func SetPrompter(p SecretPrompter) {
+	activePrompter = p
+}
+
+// ReadSecret - read a secret from the user through the active SecretPrompter,
+// into an mlock'd, wipeable SecretBytes rather than a GC-managed string
+func ReadSecret(msg string) (*SecretBytes, error) {
+	return activePrompter.Prompt(msg)
+}
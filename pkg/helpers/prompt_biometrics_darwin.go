@@ -0,0 +1,37 @@
+package helpers
+
+/*
+#cgo LDFLAGS: -framework LocalAuthentication -framework Foundation
+#include <stdlib.h>
+
+int gophkeeperEvaluateBiometrics(const char *reason, char **errOut);
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// biometricsPrompter (darwin) - gates the secret prompt behind Touch ID via
+// LocalAuthentication's LAContext, evaluated in the accompanying Objective-C shim.
+type biometricsPrompter struct {
+	tty ttyPrompter
+}
+
+// newBiometricsPrompter - always offered on darwin; LAContext itself reports
+// when no biometric sensor/enrollment is available at evaluation time
+func newBiometricsPrompter() *biometricsPrompter {
+	return &biometricsPrompter{}
+}
+
+func (p *biometricsPrompter) Prompt(msg string) (*SecretBytes, error) {
+	reason := C.CString(msg)
+	defer C.free(unsafe.Pointer(reason))
+	var cErr *C.char
+	if C.gophkeeperEvaluateBiometrics(reason, &cErr) == 0 {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, errors.New(C.GoString(cErr))
+	}
+	return p.tty.Prompt(msg)
+}
@@ -0,0 +1,10 @@
+//go:build darwin && !cgo
+
+package helpers
+
+// newBiometricsPrompter - cgo disabled (e.g. CGO_ENABLED=0 cross-build), so
+// the LocalAuthentication shim in prompt_biometrics_darwin.go isn't built;
+// fall back to no biometrics backend rather than failing to compile.
+func newBiometricsPrompter() SecretPrompter {
+	return nil
+}
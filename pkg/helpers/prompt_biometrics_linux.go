@@ -0,0 +1,26 @@
+package helpers
+
+import "os/exec"
+
+// biometricsPrompter (linux) - gates the secret prompt behind a polkit
+// authentication dialog. On desktops with fprintd configured as the polkit
+// authentication agent's default method, this surfaces as a fingerprint
+// prompt; it otherwise falls back to the user's usual polkit auth.
+type biometricsPrompter struct {
+	tty ttyPrompter
+}
+
+// newBiometricsPrompter - nil if pkexec (and therefore a polkit agent) isn't available
+func newBiometricsPrompter() *biometricsPrompter {
+	if _, err := exec.LookPath("pkexec"); err != nil {
+		return nil
+	}
+	return &biometricsPrompter{}
+}
+
+func (p *biometricsPrompter) Prompt(msg string) (*SecretBytes, error) {
+	if err := exec.Command("pkexec", "--disable-internal-agent", "true").Run(); err != nil {
+		return nil, err
+	}
+	return p.tty.Prompt(msg)
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !windows
+
+package helpers
+
+// newBiometricsPrompter - no biometrics backend on this platform
+func newBiometricsPrompter() SecretPrompter {
+	return nil
+}
@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+// biometricsPrompter (windows) - gates the secret prompt behind
+// CredUIPromptForWindowsCredentials, which on a Windows Hello enrolled
+// machine surfaces the fingerprint/face/PIN chooser rather than a plain
+// password box.
+type biometricsPrompter struct {
+	tty ttyPrompter
+}
+
+var (
+	modCredUI                              = syscall.NewLazyDLL("credui.dll")
+	procCredUIPromptForWindowsCredentialsW = modCredUI.NewProc("CredUIPromptForWindowsCredentialsW")
+	procCredUIConfirmCredentials           = modCredUI.NewProc("CredUIConfirmCredentialsW")
+
+	modOle32          = syscall.NewLazyDLL("ole32.dll")
+	procCoTaskMemFree = modOle32.NewProc("CoTaskMemFree")
+)
+
+type credUIInfo struct {
+	Size        uint32
+	Parent      uintptr
+	MessageText *uint16
+	CaptionText *uint16
+	BannerArt   uintptr
+}
+
+// newBiometricsPrompter - always offered on windows; CredUI itself reports
+// when Windows Hello is not configured and falls back to password entry
+func newBiometricsPrompter() *biometricsPrompter {
+	return &biometricsPrompter{}
+}
+
+func (p *biometricsPrompter) Prompt(msg string) (*SecretBytes, error) {
+	caption, err := syscall.UTF16PtrFromString("gophkeeper")
+	if err != nil {
+		return nil, err
+	}
+	message, err := syscall.UTF16PtrFromString(msg)
+	if err != nil {
+		return nil, err
+	}
+	info := credUIInfo{
+		Parent:      0,
+		MessageText: message,
+		CaptionText: caption,
+	}
+	info.Size = uint32(unsafe.Sizeof(info))
+
+	var authPackage uint32
+	var outCredBuffer uintptr
+	var outCredSize uint32
+	var save int32
+
+	ret, _, _ := procCredUIPromptForWindowsCredentialsW.Call(
+		uintptr(unsafe.Pointer(&info)),
+		0,
+		uintptr(unsafe.Pointer(&authPackage)),
+		0, 0,
+		uintptr(unsafe.Pointer(&outCredBuffer)),
+		uintptr(unsafe.Pointer(&outCredSize)),
+		uintptr(unsafe.Pointer(&save)),
+		1, // CREDUIWIN_GENERIC
+	)
+	if ret != 0 {
+		return nil, errors.New("windows credential prompt failed")
+	}
+	if outCredBuffer == 0 {
+		return nil, errors.New("windows credential prompt cancelled")
+	}
+	// outCredBuffer is CoTaskMemAlloc'd by CredUIPromptForWindowsCredentials;
+	// we own it and must free it once we're done reading out of it.
+	defer procCoTaskMemFree.Call(outCredBuffer)
+	// The packed credential blob only proves the user passed the CredUI check;
+	// zero it before it is freed, then read the secret itself from the TTY.
+	raw := (*(*[1 << 16]byte)(unsafe.Pointer(outCredBuffer)))[:outCredSize]
+	for i := range raw {
+		raw[i] = 0
+	}
+	return p.tty.Prompt(msg)
+}
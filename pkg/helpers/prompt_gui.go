@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+)
+
+// guiPrompter - asks through a graphical password dialog (zenity or
+// kdialog), e.g. for a desktop session launching gophkeeper from a menu
+// rather than a terminal. selectPrompter picks it ahead of the TTY whenever
+// either binary is installed; whether a TTY is attached is not checked.
+type guiPrompter struct {
+	binary string
+	args   []string
+}
+
+// newGUIPrompter - detect zenity/kdialog on PATH; returns nil if neither is installed
+func newGUIPrompter() *guiPrompter {
+	if _, err := exec.LookPath("zenity"); err == nil {
+		return &guiPrompter{binary: "zenity", args: []string{"--password"}}
+	}
+	if _, err := exec.LookPath("kdialog"); err == nil {
+		return &guiPrompter{binary: "kdialog", args: []string{"--password"}}
+	}
+	return nil
+}
+
+func (p *guiPrompter) Prompt(msg string) (*SecretBytes, error) {
+	args := append(append([]string{}, p.args...), msg)
+	out, err := exec.Command(p.binary, args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for i := range out {
+			out[i] = 0
+		}
+	}()
+	trimmed := bytes.TrimRight(out, "\n")
+	if len(trimmed) == 0 {
+		return nil, errors.New("gui prompt cancelled")
+	}
+	return SecretBytesFrom(trimmed), nil
+}
@@ -0,0 +1,130 @@
+package helpers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// pinentryPrompter - drives a GnuPG pinentry-* binary over the assuan
+// protocol (SETDESC/SETPROMPT/GETPIN, replying with D/OK/ERR lines) so the
+// secret never has to be typed into gophkeeper's own terminal/process.
+type pinentryPrompter struct {
+	binary   string
+	userData string
+}
+
+func (p *pinentryPrompter) Prompt(msg string) (*SecretBytes, error) {
+	bin := p.binary
+	if bin == "" {
+		bin = "pinentry"
+	}
+	cmd := exec.Command(bin)
+	if p.userData != "" {
+		cmd.Env = append(cmd.Environ(), "PINENTRY_USER_DATA="+p.userData)
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	defer closeAssuanSession(cmd, stdin)
+
+	reader := bufio.NewReader(stdout)
+	if _, err := readAssuanLine(reader); err != nil { // initial "OK" greeting
+		return nil, err
+	}
+
+	if err := sendAssuanCommand(stdin, reader, fmt.Sprintf("SETDESC %s", assuanEscape(msg))); err != nil {
+		return nil, err
+	}
+	if err := sendAssuanCommand(stdin, reader, fmt.Sprintf("SETPROMPT %s", assuanEscape(msg))); err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(stdin, "GETPIN\n"); err != nil {
+		return nil, err
+	}
+
+	var secret []byte
+	for {
+		line, err := readAssuanLine(reader)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case line == "OK":
+			out := SecretBytesFrom(secret)
+			for i := range secret {
+				secret[i] = 0
+			}
+			return out, nil
+		case strings.HasPrefix(line, "ERR "):
+			return nil, errors.New(strings.TrimPrefix(line, "ERR "))
+		case strings.HasPrefix(line, "D "):
+			secret = assuanUnescape(strings.TrimPrefix(line, "D "))
+		}
+	}
+}
+
+// closeAssuanSession - tell pinentry to exit (BYE) and close its stdin so it
+// isn't left waiting on the next command, then reap it off the hot path so a
+// slow/hung child can't block Prompt's caller.
+func closeAssuanSession(cmd *exec.Cmd, stdin io.WriteCloser) {
+	fmt.Fprintf(stdin, "BYE\n")
+	stdin.Close()
+	go cmd.Wait()
+}
+
+func sendAssuanCommand(w io.Writer, r *bufio.Reader, cmd string) error {
+	if _, err := fmt.Fprintf(w, "%s\n", cmd); err != nil {
+		return err
+	}
+	line, err := readAssuanLine(r)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(line, "ERR ") {
+		return errors.New(strings.TrimPrefix(line, "ERR "))
+	}
+	return nil
+}
+
+func readAssuanLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func assuanEscape(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return s
+}
+
+func assuanUnescape(s string) []byte {
+	b := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			var v int
+			if _, err := fmt.Sscanf(s[i+1:i+3], "%02X", &v); err == nil {
+				b = append(b, byte(v))
+				i += 2
+				continue
+			}
+		}
+		b = append(b, s[i])
+	}
+	return b
+}
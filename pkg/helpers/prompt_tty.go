@@ -0,0 +1,25 @@
+package helpers
+
+import (
+	"fmt"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// ttyPrompter - reads a secret from the controlling terminal with input echo
+// disabled. This is the original ReadSecret behavior.
+type ttyPrompter struct{}
+
+func (p *ttyPrompter) Prompt(msg string) (*SecretBytes, error) {
+	fmt.Print(msg)
+	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		return nil, err
+	}
+	secret := SecretBytesFrom(bytePassword)
+	for i := range bytePassword {
+		bytePassword[i] = 0
+	}
+	return secret, nil
+}
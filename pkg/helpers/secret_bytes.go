@@ -0,0 +1,55 @@
+package helpers
+
+import "runtime"
+
+// SecretBytes - a byte buffer for secret material (passphrases, derived
+// keys) that is mlock'd where the platform supports it, can be explicitly
+// Wipe()'d, and is zeroed by a finalizer if the caller forgets to.
+type SecretBytes struct {
+	b      []byte
+	locked bool
+}
+
+// NewSecretBytes - allocate a zeroed SecretBytes of size n
+func NewSecretBytes(n int) *SecretBytes {
+	s := &SecretBytes{b: make([]byte, n)}
+	s.locked = mlock(s.b) == nil
+	runtime.SetFinalizer(s, (*SecretBytes).Wipe)
+	return s
+}
+
+// SecretBytesFrom - copy data into a new SecretBytes. The caller is
+// responsible for zeroing data itself if it isn't already owned elsewhere.
+func SecretBytesFrom(data []byte) *SecretBytes {
+	s := NewSecretBytes(len(data))
+	copy(s.b, data)
+	return s
+}
+
+// Bytes - the underlying buffer. Valid until Wipe is called.
+func (s *SecretBytes) Bytes() []byte {
+	return s.b
+}
+
+// Wipe - overwrite the buffer with zeroes, unlock it, and release it. Safe to call more than once.
+func (s *SecretBytes) Wipe() {
+	if s.b == nil {
+		return
+	}
+	for i := range s.b {
+		s.b[i] = 0
+	}
+	if s.locked {
+		munlock(s.b)
+		s.locked = false
+	}
+	s.b = nil
+	runtime.SetFinalizer(s, nil)
+}
+
+// WithSecret - run fn with a fresh n-byte SecretBytes, guaranteeing Wipe on return
+func WithSecret(n int, fn func(b []byte) error) error {
+	s := NewSecretBytes(n)
+	defer s.Wipe()
+	return fn(s.Bytes())
+}
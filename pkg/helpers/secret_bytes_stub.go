@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !windows
+
+package helpers
+
+// mlock/munlock - no memory-locking support on this platform; SecretBytes
+// still zeroes on Wipe, it just isn't guaranteed to stay out of swap.
+func mlock(b []byte) error   { return nil }
+func munlock(b []byte) error { return nil }
@@ -0,0 +1,70 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSecretBytesWipeZeroesBuffer(t *testing.T) {
+	s := NewSecretBytes(16)
+	b := s.Bytes()
+	for i := range b {
+		b[i] = byte(i + 1)
+	}
+
+	s.Wipe()
+
+	for i, v := range b {
+		if v != 0 {
+			t.Fatalf("byte %d not zeroed after Wipe: %d", i, v)
+		}
+	}
+	if s.Bytes() != nil {
+		t.Fatal("Bytes() should be nil after Wipe")
+	}
+}
+
+func TestSecretBytesWipeIsIdempotent(t *testing.T) {
+	s := NewSecretBytes(8)
+	s.Wipe()
+	s.Wipe() // must not panic
+}
+
+func TestWithSecretWipesOnSuccess(t *testing.T) {
+	var captured []byte
+	err := WithSecret(8, func(b []byte) error {
+		captured = b
+		for i := range b {
+			b[i] = 0xFF
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, v := range captured {
+		if v != 0 {
+			t.Fatalf("byte %d not wiped after success: %d", i, v)
+		}
+	}
+}
+
+func TestWithSecretWipesOnError(t *testing.T) {
+	wantErr := errors.New("boom")
+	var captured []byte
+	err := WithSecret(8, func(b []byte) error {
+		captured = b
+		for i := range b {
+			b[i] = 0xFF
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+	for i, v := range captured {
+		if v != 0 {
+			t.Fatalf("byte %d not wiped after error: %d", i, v)
+		}
+	}
+}
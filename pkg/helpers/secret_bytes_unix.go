@@ -0,0 +1,19 @@
+//go:build linux || darwin
+
+package helpers
+
+import "syscall"
+
+func mlock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return syscall.Mlock(b)
+}
+
+func munlock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	return syscall.Munlock(b)
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modkernel32       = syscall.NewLazyDLL("kernel32.dll")
+	procVirtualLock   = modkernel32.NewProc("VirtualLock")
+	procVirtualUnlock = modkernel32.NewProc("VirtualUnlock")
+)
+
+func mlock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	ret, _, err := procVirtualLock.Call(uintptr(unsafe.Pointer(&b[0])), uintptr(len(b)))
+	if ret == 0 {
+		return err
+	}
+	return nil
+}
+
+func munlock(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	ret, _, err := procVirtualUnlock.Call(uintptr(unsafe.Pointer(&b[0])), uintptr(len(b)))
+	if ret == 0 {
+		return err
+	}
+	return nil
+}
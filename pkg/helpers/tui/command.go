@@ -0,0 +1,184 @@
+// Package tui implements the interactive command dispatcher used by the
+// gophkeeper shell: command registration, fuzzy lookup, completion and
+// persistent history.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ArgSpec - describes one positional argument of a Command for completion purposes
+type ArgSpec struct {
+	Name     string
+	Required bool
+	Complete func(prefix string) []string
+}
+
+// Command - a single dispatchable TUI command
+type Command struct {
+	Name     string
+	Aliases  []string
+	Args     []ArgSpec
+	Run      func(ctx context.Context, args []string) error
+	Complete func(prefix string) []string
+}
+
+func (c *Command) matches(name string) bool {
+	if c.Name == name {
+		return true
+	}
+	for _, a := range c.Aliases {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Dispatcher - registry of Commands with fuzzy lookup and completion
+type Dispatcher struct {
+	commands []*Command
+	history  *History
+	confirm  func(candidate string) bool
+}
+
+// NewDispatcher - build an empty dispatcher backed by the default history file
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{history: NewHistory("")}
+}
+
+// Register - add a command to the dispatcher
+func (d *Dispatcher) Register(cmd *Command) {
+	d.commands = append(d.commands, cmd)
+}
+
+// History - the dispatcher's persistent command history
+func (d *Dispatcher) History() *History {
+	return d.history
+}
+
+// Find - look up a command by exact name or alias
+func (d *Dispatcher) Find(name string) (*Command, bool) {
+	for _, c := range d.commands {
+		if c.matches(name) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Suggest - fuzzy-match name against registered commands, returning the best
+// "did you mean" candidate. Candidates must contain name as a subsequence;
+// ties are broken by Levenshtein distance.
+func (d *Dispatcher) Suggest(name string) (*Command, bool) {
+	var best *Command
+	bestDist := -1
+	for _, c := range d.commands {
+		if !isSubsequence(name, c.Name) {
+			continue
+		}
+		dist := levenshtein(name, c.Name)
+		if bestDist == -1 || dist < bestDist {
+			best = c
+			bestDist = dist
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+	return best, true
+}
+
+// Complete - tab-completion candidates for a partially typed input line.
+// The first word completes against command names/aliases; subsequent words
+// complete against that command's ArgSpec.Complete (or Command.Complete as
+// a fallback covering all argument positions).
+func (d *Dispatcher) Complete(line string) []string {
+	fields := strings.Fields(line)
+	trailingSpace := strings.HasSuffix(line, " ")
+	if len(fields) == 0 || (len(fields) == 1 && !trailingSpace) {
+		prefix := ""
+		if len(fields) == 1 {
+			prefix = fields[0]
+		}
+		return d.completeNames(prefix)
+	}
+	cmd, ok := d.Find(fields[0])
+	if !ok {
+		return nil
+	}
+	argIndex := len(fields) - 1
+	prefix := fields[argIndex]
+	if trailingSpace {
+		argIndex = len(fields)
+		prefix = ""
+	}
+	argIndex-- // first word is the command name itself
+	var complete func(string) []string
+	if argIndex >= 0 && argIndex < len(cmd.Args) && cmd.Args[argIndex].Complete != nil {
+		complete = cmd.Args[argIndex].Complete
+	} else {
+		complete = cmd.Complete
+	}
+	if complete == nil {
+		return nil
+	}
+	return complete(prefix)
+}
+
+func (d *Dispatcher) completeNames(prefix string) []string {
+	var out []string
+	for _, c := range d.commands {
+		if strings.HasPrefix(c.Name, prefix) {
+			out = append(out, c.Name)
+		}
+		for _, a := range c.Aliases {
+			if strings.HasPrefix(a, prefix) {
+				out = append(out, a)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Confirm - ask the user to confirm running the fuzzily-resolved candidate
+// command. Dispatch refuses to run a Suggest() result unless this returns
+// true. Defaults to confirmPromptYN (read a y/N answer from stdin).
+func (d *Dispatcher) Confirm(fn func(candidate string) bool) {
+	d.confirm = fn
+}
+
+// Dispatch - resolve line's first word to a command, running it directly on
+// an exact name/alias match. An ambiguous or misspelled name only falls back
+// to the closest fuzzy Suggest() after the user confirms it via Confirm
+// (defaulting to a "did you mean" y/N prompt) — it is never run silently,
+// since a short typo can be a subsequence of more than one registered
+// command (e.g. a destructive one). The resolved line is recorded to
+// history regardless of the run outcome.
+func (d *Dispatcher) Dispatch(ctx context.Context, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return fmt.Errorf("empty command")
+	}
+	cmd, ok := d.Find(fields[0])
+	if !ok {
+		suggestion, ok2 := d.Suggest(fields[0])
+		if !ok2 {
+			return fmt.Errorf("unknown command: %s", fields[0])
+		}
+		confirm := d.confirm
+		if confirm == nil {
+			confirm = confirmPromptYN
+		}
+		if !confirm(suggestion.Name) {
+			return fmt.Errorf("did you mean %q? not run", suggestion.Name)
+		}
+		cmd = suggestion
+	}
+	d.history.Add(line)
+	return cmd.Run(ctx, fields[1:])
+}
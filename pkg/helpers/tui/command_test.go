@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSuggestBreaksSubsequenceTieByLevenshtein(t *testing.T) {
+	d := NewDispatcher()
+	d.Register(&Command{Name: "view"})
+	d.Register(&Command{Name: "revoke"})
+
+	// "ve" is a subsequence of both "view" and "revoke"; "view" is closer.
+	got, ok := d.Suggest("ve")
+	if !ok {
+		t.Fatal("expected a suggestion")
+	}
+	if got.Name != "view" {
+		t.Fatalf("got %q, want %q", got.Name, "view")
+	}
+}
+
+func TestDispatchRefusesFuzzyMatchWithoutConfirmation(t *testing.T) {
+	d := NewDispatcher()
+	ran := false
+	d.Register(&Command{
+		Name: "revoke",
+		Run: func(ctx context.Context, args []string) error {
+			ran = true
+			return nil
+		},
+	})
+	d.Confirm(func(candidate string) bool { return false })
+
+	err := d.Dispatch(context.Background(), "revok")
+	if err == nil {
+		t.Fatal("expected an error when confirmation is declined")
+	}
+	if ran {
+		t.Fatal("command must not run when confirmation is declined")
+	}
+}
+
+func TestDispatchRunsFuzzyMatchWhenConfirmed(t *testing.T) {
+	d := NewDispatcher()
+	ran := false
+	d.Register(&Command{
+		Name: "revoke",
+		Run: func(ctx context.Context, args []string) error {
+			ran = true
+			return nil
+		},
+	})
+	d.Confirm(func(candidate string) bool { return true })
+
+	if err := d.Dispatch(context.Background(), "revok"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Fatal("command should have run once confirmed")
+	}
+}
+
+func TestCompleteArgumentPositions(t *testing.T) {
+	d := NewDispatcher()
+	d.Register(&Command{
+		Name: "insert",
+		Args: []ArgSpec{
+			{Name: "name", Complete: func(prefix string) []string { return []string{"alice", "bob"} }},
+			{Name: "field", Complete: func(prefix string) []string { return []string{"password", "note"} }},
+		},
+	})
+
+	if got := d.Complete("ins"); len(got) != 1 || got[0] != "insert" {
+		t.Fatalf("completing command name: got %v", got)
+	}
+
+	got := d.Complete("insert a")
+	if len(got) != 2 || got[0] != "alice" || got[1] != "bob" {
+		t.Fatalf("completing arg 0: got %v", got)
+	}
+
+	got = d.Complete("insert alice ")
+	if len(got) != 2 || got[0] != "password" || got[1] != "note" {
+		t.Fatalf("completing arg 1: got %v", got)
+	}
+}
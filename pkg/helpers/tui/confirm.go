@@ -0,0 +1,17 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// confirmPromptYN - the default "did you mean" confirmation: print the
+// candidate and read a y/N answer from stdin
+func confirmPromptYN(candidate string) bool {
+	fmt.Printf("did you mean %q? [y/N] ", candidate)
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.TrimSpace(strings.ToLower(line))
+	return line == "y" || line == "yes"
+}
@@ -0,0 +1,57 @@
+package tui
+
+// isSubsequence - report whether every rune of sub appears in s in order
+// (not necessarily contiguously), e.g. "insrt" is a subsequence of "insert"
+func isSubsequence(sub, s string) bool {
+	subR := []rune(sub)
+	if len(subR) == 0 {
+		return true
+	}
+	i := 0
+	for _, r := range s {
+		if r == subR[i] {
+			i++
+			if i == len(subR) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// levenshtein - edit distance between a and b, used to break ties between
+// multiple subsequence matches in Dispatcher.Suggest
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			del := prev[j] + 1
+			ins := curr[j-1] + 1
+			sub := prev[j-1] + cost
+			curr[j] = min3(del, ins, sub)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+)
+
+// defaultHistorySize - max number of lines kept in a History before the oldest are dropped
+const defaultHistorySize = 500
+
+// History - readline-style command history, persisted to a file under the config dir
+type History struct {
+	path    string
+	entries []string
+	max     int
+}
+
+// NewHistory - build a History backed by path. An empty path resolves to
+// "<os.UserConfigDir()>/gophkeeper/history". Existing entries are loaded immediately.
+func NewHistory(path string) *History {
+	if path == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "gophkeeper", "history")
+		}
+	}
+	h := &History{path: path, max: defaultHistorySize}
+	h.load()
+	return h
+}
+
+func (h *History) load() {
+	if h.path == "" {
+		return
+	}
+	f, err := os.Open(h.path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		h.entries = append(h.entries, scanner.Text())
+	}
+}
+
+// Add - append a line to the in-memory history and persist it
+func (h *History) Add(line string) {
+	if line == "" {
+		return
+	}
+	h.entries = append(h.entries, line)
+	if len(h.entries) > h.max {
+		h.entries = h.entries[len(h.entries)-h.max:]
+	}
+	_ = h.save()
+}
+
+// All - every recorded entry, oldest first
+func (h *History) All() []string {
+	return h.entries
+}
+
+func (h *History) save() error {
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	for _, e := range h.entries {
+		if _, err := w.WriteString(e + "\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// RunLoop - read lines from in and dispatch them until EOF or ctx is done.
+// onInterrupt is invoked every time the user presses Ctrl-C (SIGINT), so the
+// caller can always restore terminal state before the process exits or the
+// loop continues to the next prompt.
+func (d *Dispatcher) RunLoop(ctx context.Context, in io.Reader, out io.Writer, prompt string, onInterrupt func()) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT)
+	defer signal.Stop(sigCh)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			select {
+			case <-sigCh:
+				if onInterrupt != nil {
+					onInterrupt()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, prompt)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if err := d.Dispatch(ctx, line); err != nil {
+			fmt.Fprintln(out, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package helpers
+
+import (
+	"sort"
+
+	"github.com/t1mon-ggg/gophkeeper/pkg/models"
+)
+
+// Hash - a models.Version content hash, used as a node key in a VersionDAG
+type Hash = string
+
+// DedupVersions - remove duplicate versions in O(n) using a map keyed by
+// Hash. Content is immutable, so on a collision the earliest Date wins
+// rather than the latest; OnlyOne previously kept the newest.
+func DedupVersions(in []models.Version) []models.Version {
+	seen := make(map[Hash]models.Version, len(in))
+	order := make([]Hash, 0, len(in))
+	for _, v := range in {
+		existing, ok := seen[v.Hash]
+		if !ok {
+			order = append(order, v.Hash)
+			seen[v.Hash] = v
+			continue
+		}
+		if v.Date.Before(existing.Date) {
+			seen[v.Hash] = v
+		}
+	}
+	out := make([]models.Version, 0, len(order))
+	for _, h := range order {
+		out = append(out, seen[h])
+	}
+	return out
+}
+
+// OnlyOne - deprecated alias for DedupVersions, kept for compatibility
+func OnlyOne(in []models.Version) []models.Version {
+	return DedupVersions(in)
+}
+
+// VersionDAG - the Merkle history of an entry's versions, keyed by Hash with
+// edges following Version.Parent
+type VersionDAG struct {
+	nodes map[Hash]models.Version
+}
+
+// NewVersionDAG - build a VersionDAG from a (possibly duplicated) set of versions
+func NewVersionDAG(versions []models.Version) *VersionDAG {
+	deduped := DedupVersions(versions)
+	nodes := make(map[Hash]models.Version, len(deduped))
+	for _, v := range deduped {
+		nodes[v.Hash] = v
+	}
+	return &VersionDAG{nodes: nodes}
+}
+
+// TopoSort - all versions ordered so that every version appears after its
+// Parent; ties among versions with no remaining dependency are broken by Hash
+// for a deterministic result.
+func (g *VersionDAG) TopoSort() []models.Version {
+	children := make(map[Hash][]Hash, len(g.nodes))
+	indegree := make(map[Hash]int, len(g.nodes))
+	for h := range g.nodes {
+		indegree[h] = 0
+	}
+	for h, v := range g.nodes {
+		if v.Parent == "" {
+			continue
+		}
+		if _, ok := g.nodes[v.Parent]; !ok {
+			continue
+		}
+		children[v.Parent] = append(children[v.Parent], h)
+		indegree[h]++
+	}
+
+	var ready []Hash
+	for h, d := range indegree {
+		if d == 0 {
+			ready = append(ready, h)
+		}
+	}
+	sort.Strings(ready)
+
+	out := make([]models.Version, 0, len(g.nodes))
+	for len(ready) > 0 {
+		h := ready[0]
+		ready = ready[1:]
+		out = append(out, g.nodes[h])
+
+		next := children[h]
+		sort.Strings(next)
+		for _, c := range next {
+			indegree[c]--
+			if indegree[c] == 0 {
+				ready = append(ready, c)
+			}
+		}
+		sort.Strings(ready)
+	}
+	return out
+}
+
+// LCA - the lowest common ancestor of a and b, walking each Version's Parent
+// chain. Returns false if either hash is unknown or they share no ancestor.
+func (g *VersionDAG) LCA(a, b Hash) (Hash, bool) {
+	ancestorsOfA := make(map[Hash]bool)
+	for cur := a; cur != ""; {
+		v, ok := g.nodes[cur]
+		if !ok {
+			break
+		}
+		ancestorsOfA[cur] = true
+		cur = v.Parent
+	}
+
+	for cur := b; cur != ""; {
+		if ancestorsOfA[cur] {
+			return cur, true
+		}
+		v, ok := g.nodes[cur]
+		if !ok {
+			break
+		}
+		cur = v.Parent
+	}
+	return "", false
+}
+
+// Diff - the versions reachable from b back to the LCA of a and b, exclusive
+// of the LCA itself and newest-first; this is what b added relative to a.
+func (g *VersionDAG) Diff(a, b Hash) []models.Version {
+	lca, _ := g.LCA(a, b)
+	var out []models.Version
+	for cur := b; cur != "" && cur != lca; {
+		v, ok := g.nodes[cur]
+		if !ok {
+			break
+		}
+		out = append(out, v)
+		cur = v.Parent
+	}
+	return out
+}
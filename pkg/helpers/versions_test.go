@@ -0,0 +1,74 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/t1mon-ggg/gophkeeper/pkg/models"
+)
+
+func mkVersion(content []byte, parent string, date time.Time) models.Version {
+	return models.NewVersion(content, parent, date)
+}
+
+func TestDedupVersionsKeepsEarliestDate(t *testing.T) {
+	base := time.Now()
+	v1 := mkVersion([]byte("a"), "", base)
+	dup := v1
+	dup.Date = base.Add(time.Hour) // same Hash, later Date
+
+	out := DedupVersions([]models.Version{dup, v1})
+	if len(out) != 1 {
+		t.Fatalf("len(out) = %d, want 1", len(out))
+	}
+	if !out[0].Date.Equal(base) {
+		t.Errorf("Date = %v, want earliest %v", out[0].Date, base)
+	}
+}
+
+func TestVersionDAGTopoSortParentsBeforeChildren(t *testing.T) {
+	base := time.Now()
+	root := mkVersion([]byte("root"), "", base)
+	mid := mkVersion([]byte("mid"), root.Hash, base.Add(time.Minute))
+	leaf := mkVersion([]byte("leaf"), mid.Hash, base.Add(2*time.Minute))
+
+	dag := NewVersionDAG([]models.Version{leaf, root, mid})
+	order := dag.TopoSort()
+	if len(order) != 3 {
+		t.Fatalf("len(order) = %d, want 3", len(order))
+	}
+
+	pos := make(map[string]int, len(order))
+	for i, v := range order {
+		pos[v.Hash] = i
+	}
+	if pos[root.Hash] > pos[mid.Hash] || pos[mid.Hash] > pos[leaf.Hash] {
+		t.Errorf("TopoSort did not order root < mid < leaf: %v", order)
+	}
+}
+
+func TestVersionDAGLCAAndDiff(t *testing.T) {
+	base := time.Now()
+	root := mkVersion([]byte("root"), "", base)
+	branchA := mkVersion([]byte("a"), root.Hash, base.Add(time.Minute))
+	branchB := mkVersion([]byte("b"), root.Hash, base.Add(2*time.Minute))
+	tipA := mkVersion([]byte("a2"), branchA.Hash, base.Add(3*time.Minute))
+
+	dag := NewVersionDAG([]models.Version{root, branchA, branchB, tipA})
+
+	lca, ok := dag.LCA(branchB.Hash, tipA.Hash)
+	if !ok {
+		t.Fatal("LCA: expected a common ancestor")
+	}
+	if lca != root.Hash {
+		t.Errorf("LCA = %q, want %q", lca, root.Hash)
+	}
+
+	diff := dag.Diff(branchB.Hash, tipA.Hash)
+	if len(diff) != 2 {
+		t.Fatalf("len(diff) = %d, want 2 (tipA, branchA)", len(diff))
+	}
+	if diff[0].Hash != tipA.Hash || diff[1].Hash != branchA.Hash {
+		t.Errorf("Diff = %v, want [tipA, branchA]", diff)
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+// Version - a single content-addressed point in an entry's edit history.
+// Content is the versioned payload itself (an entry's encrypted bytes).
+// Hash and Parent link Versions into a Merkle DAG: Hash is the sha256 of
+// Content plus Parent, and Parent is empty for a root version.
+type Version struct {
+	Parent  string
+	Hash    string
+	Date    time.Time
+	Content []byte
+}
+
+// NewVersion - build a Version over content with the given parent hash and
+// date, computing Hash so it never drifts from its Content/Parent
+func NewVersion(content []byte, parent string, date time.Time) Version {
+	return Version{
+		Parent:  parent,
+		Hash:    ComputeHash(content, parent),
+		Date:    date,
+		Content: content,
+	}
+}
+
+// ComputeHash - sha256 of content followed by parent, the hash a Version
+// carrying this content and parent must have to be valid in the DAG
+func ComputeHash(content []byte, parent string) string {
+	h := sha256.New()
+	h.Write(content)
+	h.Write([]byte(parent))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}